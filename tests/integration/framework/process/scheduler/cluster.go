@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/tests/integration/framework/client"
+	"github.com/dapr/dapr/tests/integration/framework/process/ports"
+)
+
+// SchedulerCluster is a collection of Scheduler processes sharing a single
+// `--initial-cluster` so that tests can exercise quorum changes, leader
+// failover, and rolling restarts against a realistic multi-member etcd
+// raft group.
+type SchedulerCluster struct {
+	members []*Scheduler
+
+	// etcdClients is keyed by member ID and dialed once per member in Run,
+	// since member addresses are stable across restarts; Leader/Followers
+	// reuse these rather than redialing on every poll.
+	etcdClients map[string]*client.EtcdClient
+}
+
+// NewCluster builds a SchedulerCluster of the given size. opts are applied
+// to every member in addition to the per-member wiring (id, initial
+// cluster, and etcd client ports) that NewCluster derives itself.
+func NewCluster(t *testing.T, size int, opts ...Option) *SchedulerCluster {
+	t.Helper()
+
+	require.Greater(t, size, 0, "cluster size must be positive")
+
+	ids := make([]string, size)
+	for i := range ids {
+		uid, err := uuid.NewUUID()
+		require.NoError(t, err)
+		ids[i] = uid.String() + "-" + strconv.Itoa(i)
+	}
+
+	fp := ports.Reserve(t, size*2)
+
+	initialClusterParts := make([]string, size)
+	for i, id := range ids {
+		initialClusterParts[i] = id + "=http://127.0.0.1:" + strconv.Itoa(fp.Port(t))
+	}
+	initialCluster := strings.Join(initialClusterParts, ",")
+
+	etcdClientPorts := make([]string, size)
+	for i, id := range ids {
+		etcdClientPorts[i] = id + "=" + strconv.Itoa(fp.Port(t))
+	}
+
+	// fp only exists to mint the port numbers baked into initialCluster and
+	// etcdClientPorts above; free it now so each member's embedded etcd can
+	// actually bind those ports when it starts, matching the same
+	// reserve-then-free pattern New uses for its own ports.
+	fp.Free(t)
+
+	members := make([]*Scheduler, size)
+	for i, id := range ids {
+		memberOpts := append([]Option{
+			WithID(id),
+			WithReplicaCount(uint32(size)),
+			WithInitialCluster(initialCluster),
+			WithEtcdClientPorts(etcdClientPorts),
+		}, opts...)
+		members[i] = New(t, memberOpts...)
+	}
+
+	return &SchedulerCluster{members: members}
+}
+
+// All returns every member of the cluster, in the order they were created.
+func (c *SchedulerCluster) All() []*Scheduler {
+	return c.members
+}
+
+// Run starts every member and waits for them all to report healthy.
+func (c *SchedulerCluster) Run(t *testing.T, ctx context.Context) {
+	t.Helper()
+
+	for _, m := range c.members {
+		m.Run(t, ctx)
+	}
+	for _, m := range c.members {
+		m.WaitUntilRunning(t, ctx)
+	}
+
+	if c.etcdClients == nil {
+		c.etcdClients = make(map[string]*client.EtcdClient, len(c.members))
+		for _, m := range c.members {
+			c.etcdClients[m.ID()] = m.ETCDClient(t)
+		}
+	}
+}
+
+// Cleanup tears down every member of the cluster.
+func (c *SchedulerCluster) Cleanup(t *testing.T) {
+	t.Helper()
+
+	for _, m := range c.members {
+		m.Cleanup(t)
+	}
+}
+
+// Leader returns the member that currently identifies itself as the etcd
+// raft leader, or nil if none does (e.g. mid-election). Use
+// WaitLeaderElection to block until a leader is settled.
+func (c *SchedulerCluster) Leader(t *testing.T, ctx context.Context) *Scheduler {
+	t.Helper()
+
+	for _, m := range c.members {
+		if !m.running.Load() {
+			continue
+		}
+
+		cli := c.etcdClients[m.ID()]
+		if cli == nil {
+			continue
+		}
+
+		resp, err := cli.Status(ctx, "127.0.0.1:"+m.EtcdClientPort())
+		if err != nil {
+			continue
+		}
+
+		if resp.Leader == resp.Header.GetMemberId() {
+			return m
+		}
+	}
+
+	return nil
+}
+
+// Followers returns every running member which is not the current leader.
+func (c *SchedulerCluster) Followers(t *testing.T, ctx context.Context) []*Scheduler {
+	t.Helper()
+
+	leader := c.Leader(t, ctx)
+
+	followers := make([]*Scheduler, 0, len(c.members))
+	for _, m := range c.members {
+		if !m.running.Load() || m == leader {
+			continue
+		}
+		followers = append(followers, m)
+	}
+
+	return followers
+}
+
+// WaitLeaderElection blocks until the cluster has settled on a leader and
+// returns it.
+func (c *SchedulerCluster) WaitLeaderElection(t *testing.T, ctx context.Context) *Scheduler {
+	t.Helper()
+
+	var leader *Scheduler
+	assert.Eventually(t, func() bool {
+		leader = c.Leader(t, ctx)
+		return leader != nil
+	}, time.Minute, 10*time.Millisecond, "cluster did not elect a leader in time")
+
+	return leader
+}
+
+// Member returns the cluster member with the given id.
+func (c *SchedulerCluster) Member(t *testing.T, id string) *Scheduler {
+	t.Helper()
+
+	for _, m := range c.members {
+		if m.ID() == id {
+			return m
+		}
+	}
+
+	t.Fatalf("no cluster member with id %q", id)
+	return nil
+}
+
+// StopMember stops the member with the given id, simulating a node failure
+// or planned shutdown without removing it from the cluster's membership.
+func (c *SchedulerCluster) StopMember(t *testing.T, id string) {
+	t.Helper()
+
+	c.Member(t, id).Cleanup(t)
+}
+
+// RestartMember starts a previously stopped member back up and waits for it
+// to rejoin the cluster and report healthy.
+func (c *SchedulerCluster) RestartMember(t *testing.T, ctx context.Context, id string) {
+	t.Helper()
+
+	m := c.Member(t, id)
+	m.Run(t, ctx)
+	m.WaitUntilRunning(t, ctx)
+}
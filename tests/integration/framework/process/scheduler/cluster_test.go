@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewClusterWiring exercises NewCluster's static wiring (distinct IDs, a
+// shared initial cluster shared by every member, and the matching
+// etcd-client-ports entry for each member), without starting any process.
+// Rolling-restart/kill-leader failover behaviour requires a running cluster
+// and belongs in the tests/integration/suite/scheduler end-to-end suite.
+func TestNewClusterWiring(t *testing.T) {
+	t.Parallel()
+
+	const size = 3
+	c := NewCluster(t, size)
+
+	require.Len(t, c.All(), size)
+
+	seenIDs := make(map[string]bool, size)
+	for _, m := range c.All() {
+		assert.False(t, seenIDs[m.ID()], "duplicate member id %q", m.ID())
+		seenIDs[m.ID()] = true
+
+		assert.Contains(t, m.InitialCluster(), m.ID()+"=http://127.0.0.1:",
+			"member %q should appear in its own initial-cluster string", m.ID())
+		assert.NotEmpty(t, m.EtcdClientPort(),
+			"member %q should have its own etcd-client-ports entry", m.ID())
+
+		for _, other := range c.All() {
+			assert.Equal(t, m.InitialCluster(), other.InitialCluster(),
+				"every member should share the same initial-cluster string")
+		}
+	}
+
+	assert.Equal(t, size, strings.Count(c.All()[0].InitialCluster(), "="))
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Out of scope: this file only adds the test-harness client for failpoints
+// (the HTTP calls a test would make). The build-tag-gated failpoint
+// injection sites in the scheduler binary itself (e.g. gofail markers like
+// beforeEtcdCommit/triggerDispatchDelay), the /failpoints/<name> HTTP
+// handler that backs them, and the Make target that produces a
+// failpoint-enabled binary are not part of this change and must land
+// separately; until they do, EnableFailpoint/DisableFailpoint will fail
+// against any scheduler binary built without that support.
+
+// EnableFailpoint arms the named failpoint with the given gofail term (e.g.
+// `sleep(100)`, `return(true)`, `panic`), by POSTing to the scheduler's
+// /failpoints/<name> endpoint. The endpoint only exists on a binary built
+// with the failpoint build tag; call this only against a Scheduler started
+// via binary.EnvValue("scheduler") with DAPR_SCHEDULER_FAILPOINT=1 set.
+func (s *Scheduler) EnableFailpoint(t *testing.T, ctx context.Context, name, term string) {
+	t.Helper()
+
+	s.doFailpointRequest(t, ctx, name, term)
+}
+
+// DisableFailpoint deactivates a previously enabled failpoint, by POSTing an
+// empty term to the same /failpoints/<name> endpoint EnableFailpoint uses.
+func (s *Scheduler) DisableFailpoint(t *testing.T, ctx context.Context, name string) {
+	t.Helper()
+
+	s.doFailpointRequest(t, ctx, name, "")
+}
+
+func (s *Scheduler) doFailpointRequest(t *testing.T, ctx context.Context, name, term string) {
+	t.Helper()
+
+	addr := fmt.Sprintf("http://127.0.0.1:%d/failpoints/%s", s.healthzPort, url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr, strings.NewReader(term))
+	require.NoError(t, err)
+
+	resp, err := s.httpClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equalf(t, http.StatusOK, resp.StatusCode,
+		"failpoint endpoint returned %d for POST %s (term %q); scheduler binary must be built with the "+
+			"failpoint tag and run with DAPR_SCHEDULER_FAILPOINT=1", resp.StatusCode, name, term)
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Out of scope: this file, plus the Jobs helper in scheduler.go, only add
+// the client side of ListJobs (local parsing of the filter syntax, and a
+// paginating wrapper around the RPC call). The ListJobs proto message, its
+// scheduler-side handler, and the etcd-backed AST evaluator that would make
+// a real scheduler binary understand this RPC are not part of this change
+// and must land separately; until they do, Jobs will fail against any real
+// scheduler as an unimplemented RPC.
+
+// FilterExpr is a node in a parsed ListJobs filter expression, e.g.
+// `namespace == "prod" and app_id matches "checkout-.*"`. Evaluation against
+// the etcd job index happens server-side in the scheduler's ListJobs RPC
+// handler; parsing the expression here only lets callers fail fast on a
+// malformed filter instead of waiting on a round trip.
+type FilterExpr interface {
+	fmt.Stringer
+	filterExpr()
+}
+
+type (
+	// IdentExpr references a job attribute, e.g. namespace or app_id.
+	IdentExpr struct{ Name string }
+
+	// LiteralExpr is a string, numeric, or boolean literal.
+	LiteralExpr struct{ Value any }
+
+	// CallExpr is a function call, e.g. now() or duration("5m").
+	CallExpr struct {
+		Name string
+		Args []FilterExpr
+	}
+
+	// UnaryExpr is a prefix operator, currently only `not`.
+	UnaryExpr struct {
+		Op   string
+		Expr FilterExpr
+	}
+
+	// BinaryExpr is an infix operator: comparisons, `matches`, `in`, `and`,
+	// `or`.
+	BinaryExpr struct {
+		Op          string
+		Left, Right FilterExpr
+	}
+
+	// ListExpr is a parenthesized, comma-separated set literal, used as the
+	// right-hand side of the `in` operator.
+	ListExpr struct {
+		Items []FilterExpr
+	}
+)
+
+func (IdentExpr) filterExpr()   {}
+func (LiteralExpr) filterExpr() {}
+func (CallExpr) filterExpr()    {}
+func (UnaryExpr) filterExpr()   {}
+func (BinaryExpr) filterExpr()  {}
+func (ListExpr) filterExpr()    {}
+
+func (e IdentExpr) String() string { return e.Name }
+func (e LiteralExpr) String() string {
+	if s, ok := e.Value.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprintf("%v", e.Value)
+}
+
+func (e CallExpr) String() string {
+	args := make([]string, len(e.Args))
+	for i, a := range e.Args {
+		args[i] = a.String()
+	}
+	return e.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (e UnaryExpr) String() string { return "(" + e.Op + " " + e.Expr.String() + ")" }
+func (e BinaryExpr) String() string {
+	return "(" + e.Left.String() + " " + e.Op + " " + e.Right.String() + ")"
+}
+
+func (e ListExpr) String() string {
+	items := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		items[i] = item.String()
+	}
+	return "(" + strings.Join(items, ", ") + ")"
+}
+
+// operator precedence, low to high.
+const (
+	precLowest = iota
+	precOr
+	precAnd
+	precNot
+	precCompare
+)
+
+var precedence = map[string]int{
+	"or":      precOr,
+	"and":     precAnd,
+	"==":      precCompare,
+	"!=":      precCompare,
+	"<":       precCompare,
+	"<=":      precCompare,
+	">":       precCompare,
+	">=":      precCompare,
+	"matches": precCompare,
+	"in":      precCompare,
+}
+
+// ParseFilter parses a ListJobs filter expression into a FilterExpr using a
+// small hand-rolled Pratt parser. It supports the comparison operators ==,
+// !=, <, <=, >, >=, the regex operator matches, the set operator in, the
+// boolean operators and/or/not, and the functions now() and duration(...).
+func ParseFilter(input string) (FilterExpr, error) {
+	p := &filterParser{tokens: tokenizeFilter(input)}
+
+	expr, err := p.parseExpr(precLowest)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, fmt.Errorf("filter: unexpected trailing token %q", p.peek().text)
+	}
+
+	return expr, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) peek() filterToken {
+	if p.pos >= len(p.tokens) {
+		return filterToken{kind: tokEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) advance() filterToken {
+	tok := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *filterParser) parseExpr(minPrec int) (FilterExpr, error) {
+	left, err := p.parsePrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+		if tok.kind != tokOp && tok.kind != tokKeyword {
+			break
+		}
+
+		op := strings.ToLower(tok.text)
+		prec, isBinary := precedence[op]
+		if !isBinary || prec < minPrec {
+			break
+		}
+
+		p.advance()
+		right, err := p.parseExpr(prec + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = BinaryExpr{Op: op, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parsePrefix() (FilterExpr, error) {
+	tok := p.peek()
+
+	switch {
+	case tok.kind == tokKeyword && strings.EqualFold(tok.text, "not"):
+		p.advance()
+		expr, err := p.parseExpr(precNot)
+		if err != nil {
+			return nil, err
+		}
+		return UnaryExpr{Op: "not", Expr: expr}, nil
+
+	case tok.kind == tokLParen:
+		p.advance()
+
+		var items []FilterExpr
+		for p.peek().kind != tokRParen {
+			item, err := p.parseExpr(precLowest)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+
+			if p.peek().kind == tokComma {
+				p.advance()
+				continue
+			}
+			break
+		}
+
+		if p.peek().kind != tokRParen {
+			return nil, fmt.Errorf("filter: expected ')', got %q", p.peek().text)
+		}
+		p.advance()
+
+		// A single parenthesized expression is grouping; two or more
+		// comma-separated items form a set literal for `in`.
+		if len(items) == 1 {
+			return items[0], nil
+		}
+		return ListExpr{Items: items}, nil
+
+	case tok.kind == tokString:
+		p.advance()
+		return LiteralExpr{Value: tok.text}, nil
+
+	case tok.kind == tokNumber:
+		p.advance()
+		n, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("filter: invalid number %q: %w", tok.text, err)
+		}
+		return LiteralExpr{Value: n}, nil
+
+	case tok.kind == tokIdent:
+		p.advance()
+		if p.peek().kind == tokLParen {
+			return p.parseCall(tok.text)
+		}
+		return IdentExpr{Name: tok.text}, nil
+
+	default:
+		return nil, fmt.Errorf("filter: unexpected token %q", tok.text)
+	}
+}
+
+func (p *filterParser) parseCall(name string) (FilterExpr, error) {
+	p.advance() // consume '('
+
+	var args []FilterExpr
+	for p.peek().kind != tokRParen {
+		arg, err := p.parseExpr(precLowest)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+
+		if p.peek().kind == tokComma {
+			p.advance()
+			continue
+		}
+		break
+	}
+
+	if p.peek().kind != tokRParen {
+		return nil, fmt.Errorf("filter: expected ')' to close call to %s(...)", name)
+	}
+	p.advance()
+
+	return CallExpr{Name: name, Args: args}, nil
+}
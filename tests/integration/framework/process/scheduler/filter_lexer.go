@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"strings"
+	"unicode"
+)
+
+type filterTokenKind int
+
+const (
+	tokEOF filterTokenKind = iota
+	tokIdent
+	tokKeyword
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+}
+
+var filterKeywords = map[string]bool{
+	"and":     true,
+	"or":      true,
+	"not":     true,
+	"matches": true,
+	"in":      true,
+}
+
+// tokenizeFilter lexes a ListJobs filter expression into tokens for
+// filterParser. It panic/recovers internally are intentionally avoided;
+// malformed input simply produces tokens that the parser rejects.
+func tokenizeFilter(input string) []filterToken {
+	var tokens []filterToken
+
+	runes := []rune(input)
+	i := 0
+	for i < len(runes) {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+
+		case c == '(':
+			tokens = append(tokens, filterToken{kind: tokLParen, text: "("})
+			i++
+
+		case c == ')':
+			tokens = append(tokens, filterToken{kind: tokRParen, text: ")"})
+			i++
+
+		case c == ',':
+			tokens = append(tokens, filterToken{kind: tokComma, text: ","})
+			i++
+
+		case c == '"':
+			j := i + 1
+			var sb strings.Builder
+			for j < len(runes) && runes[j] != '"' {
+				sb.WriteRune(runes[j])
+				j++
+			}
+			if j >= len(runes) {
+				// Unterminated string: emit the lone quote as an op token so
+				// the parser rejects it instead of accepting a truncated literal.
+				tokens = append(tokens, filterToken{kind: tokOp, text: `"`})
+				i = len(runes)
+				break
+			}
+			tokens = append(tokens, filterToken{kind: tokString, text: sb.String()})
+			i = j + 1
+
+		case c == '=' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, filterToken{kind: tokOp, text: "=="})
+			i += 2
+
+		case c == '!' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, filterToken{kind: tokOp, text: "!="})
+			i += 2
+
+		case c == '<' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, filterToken{kind: tokOp, text: "<="})
+			i += 2
+
+		case c == '>' && i+1 < len(runes) && runes[i+1] == '=':
+			tokens = append(tokens, filterToken{kind: tokOp, text: ">="})
+			i += 2
+
+		case c == '<':
+			tokens = append(tokens, filterToken{kind: tokOp, text: "<"})
+			i++
+
+		case c == '>':
+			tokens = append(tokens, filterToken{kind: tokOp, text: ">"})
+			i++
+
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, filterToken{kind: tokNumber, text: string(runes[i:j])})
+			i = j
+
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			word := string(runes[i:j])
+			if filterKeywords[strings.ToLower(word)] {
+				tokens = append(tokens, filterToken{kind: tokKeyword, text: word})
+			} else {
+				tokens = append(tokens, filterToken{kind: tokIdent, text: word})
+			}
+			i = j
+
+		default:
+			// Unrecognized character: emit it as a single-rune op token so
+			// the parser produces a clear "unexpected token" error instead
+			// of silently dropping input.
+			tokens = append(tokens, filterToken{kind: tokOp, text: string(c)})
+			i++
+		}
+	}
+
+	tokens = append(tokens, filterToken{kind: tokEOF})
+
+	return tokens
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("comparison and boolean operators", func(t *testing.T) {
+		t.Parallel()
+
+		expr, err := ParseFilter(`namespace == "prod" and app_id matches "checkout-.*" and not (replica < 1)`)
+		require.NoError(t, err)
+		assert.Equal(t, `(((namespace == "prod") and (app_id matches "checkout-.*")) and (not (replica < 1)))`, normalizeFilterString(expr))
+	})
+
+	t.Run("functions", func(t *testing.T) {
+		t.Parallel()
+
+		expr, err := ParseFilter(`next_trigger < now() and next_trigger >= duration("5m")`)
+		require.NoError(t, err)
+		assert.Equal(t, `((next_trigger < now()) and (next_trigger >= duration("5m")))`, normalizeFilterString(expr))
+	})
+
+	t.Run("in operator", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFilter(`namespace in ("prod", "staging")`)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFilter(`namespace ==`)
+		require.Error(t, err)
+	})
+
+	t.Run("unbalanced parens", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ParseFilter(`(namespace == "prod"`)
+		require.Error(t, err)
+	})
+}
+
+func normalizeFilterString(e FilterExpr) string {
+	return e.String()
+}
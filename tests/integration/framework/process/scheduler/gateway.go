@@ -0,0 +1,70 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// Out of scope: this file only adds the test-harness client side of the
+// grpc-gateway sidecar (the flags to request it, and a WebSocket dialer).
+// The scheduler binary does not yet start a grpc-gateway sidecar or accept
+// --http-gateway-port/--gateway-max-response-body-bytes; that server-side
+// work must land separately before WithHTTPGateway/WSClient do anything
+// useful against a real scheduler process.
+
+// defaultGatewayMaxResponseBodyBytes is comfortably above the 64 KiB
+// default buffer size that grpc-gateway proxies ship with, so that a test
+// opting into WithHTTPGateway without an explicit size doesn't silently
+// reproduce that truncation bug.
+const defaultGatewayMaxResponseBodyBytes = 4 << 20 // 4 MiB
+
+// WithHTTPGateway starts the scheduler with a grpc-gateway sidecar on port,
+// exposing streaming RPCs such as WatchJobs over HTTP/WebSocket. maxMsgBytes
+// sets --gateway-max-response-body-bytes; a value <= 0 falls back to
+// defaultGatewayMaxResponseBodyBytes.
+func WithHTTPGateway(port, maxMsgBytes int) Option {
+	return func(o *options) {
+		o.httpGatewayPort = &port
+		o.httpGatewayMaxMsgBytes = maxMsgBytes
+	}
+}
+
+// HTTPGatewayAddress returns the address of the scheduler's grpc-gateway
+// sidecar, started via WithHTTPGateway. It requires t to fail the test
+// clearly if the scheduler wasn't started with that option, rather than
+// panicking on a nil pointer.
+func (s *Scheduler) HTTPGatewayAddress(t *testing.T) string {
+	t.Helper()
+
+	require.NotNil(t, s.httpGatewayPort, "scheduler was not started with WithHTTPGateway")
+
+	return s.ipPort(*s.httpGatewayPort)
+}
+
+// WSClient dials the scheduler's grpc-gateway sidecar over WebSocket, for
+// reading streaming RPCs such as WatchJobs.
+func (s *Scheduler) WSClient(t *testing.T, ctx context.Context) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, "ws://"+s.HTTPGatewayAddress(t)+"/v1/jobs/watch", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, conn.Close()) })
+
+	return conn
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+)
+
+// Exemplar is an OpenMetrics exemplar attached to a histogram bucket,
+// correlating a sampled observation back to the trace that produced it.
+type Exemplar struct {
+	TraceID string
+	SpanID  string
+	Value   float64
+}
+
+// MetricsSnapshot is a parsed scrape of the scheduler's metrics endpoint,
+// negotiated as OpenMetrics where available so that exemplars are
+// preserved alongside the raw metric families.
+type MetricsSnapshot struct {
+	families map[string]*dto.MetricFamily
+}
+
+// MetricsV2 scrapes the scheduler's metrics endpoint using OpenMetrics
+// content negotiation and returns a MetricsSnapshot that supports quantile
+// estimation and exemplar correlation. Metrics retains its flat
+// map[string]float64 shape for existing callers.
+func (s *Scheduler) MetricsV2(t *testing.T, ctx context.Context) *MetricsSnapshot {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/metrics", s.MetricsAddress()), nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeOpenMetrics)))
+
+	resp, err := s.httpClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	defer resp.Body.Close()
+
+	dec := expfmt.NewDecoder(resp.Body, expfmt.ResponseFormat(resp.Header))
+
+	families := make(map[string]*dto.MetricFamily)
+	for {
+		var mf dto.MetricFamily
+		if err := dec.Decode(&mf); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+		}
+		families[mf.GetName()] = &mf
+	}
+
+	return &MetricsSnapshot{families: families}
+}
+
+// Quantile estimates the q-th quantile (0-1) of the named histogram metric
+// matching labels, linearly interpolating between bucket boundaries the
+// same way Prometheus' histogram_quantile does. ok is false if no matching
+// histogram was found.
+func (m *MetricsSnapshot) Quantile(name string, labels map[string]string, q float64) (value float64, ok bool) {
+	mf, found := m.families[name]
+	if !found {
+		return 0, false
+	}
+
+	for _, metric := range mf.GetMetric() {
+		if !matchLabels(metric.GetLabel(), labels) {
+			continue
+		}
+
+		h := metric.GetHistogram()
+		if h == nil {
+			return 0, false
+		}
+
+		return bucketQuantile(q, h.GetBucket(), h.GetSampleCount()), true
+	}
+
+	return 0, false
+}
+
+// Exemplars returns the exemplars attached to the buckets of the named
+// histogram metric matching labels.
+func (m *MetricsSnapshot) Exemplars(name string, labels map[string]string) []Exemplar {
+	mf, found := m.families[name]
+	if !found {
+		return nil
+	}
+
+	var exemplars []Exemplar
+	for _, metric := range mf.GetMetric() {
+		if !matchLabels(metric.GetLabel(), labels) {
+			continue
+		}
+
+		for _, b := range metric.GetHistogram().GetBucket() {
+			ex := b.GetExemplar()
+			if ex == nil {
+				continue
+			}
+
+			e := Exemplar{Value: ex.GetValue()}
+			for _, l := range ex.GetLabel() {
+				switch l.GetName() {
+				case "trace_id":
+					e.TraceID = l.GetValue()
+				case "span_id":
+					e.SpanID = l.GetValue()
+				}
+			}
+			exemplars = append(exemplars, e)
+		}
+	}
+
+	return exemplars
+}
+
+func matchLabels(have []*dto.LabelPair, want map[string]string) bool {
+	if len(want) == 0 {
+		return true
+	}
+
+	values := make(map[string]string, len(have))
+	for _, l := range have {
+		values[l.GetName()] = l.GetValue()
+	}
+
+	for k, v := range want {
+		if values[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bucketQuantile implements Prometheus' histogram_quantile linear
+// interpolation for a single histogram's cumulative buckets.
+func bucketQuantile(q float64, buckets []*dto.Bucket, totalCount uint64) float64 {
+	if totalCount == 0 || len(buckets) == 0 {
+		return 0
+	}
+
+	rank := q * float64(totalCount)
+
+	var prevCount uint64
+	var prevBound float64
+	for _, b := range buckets {
+		count := b.GetCumulativeCount()
+		upperBound := b.GetUpperBound()
+
+		if float64(count) >= rank {
+			if math.IsInf(upperBound, 1) {
+				return prevBound
+			}
+			if count == prevCount {
+				return upperBound
+			}
+			return prevBound + (upperBound-prevBound)*(rank-float64(prevCount))/float64(count-prevCount)
+		}
+
+		prevCount = count
+		prevBound = upperBound
+	}
+
+	return prevBound
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"math"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestHistogramSnapshot() *MetricsSnapshot {
+	return &MetricsSnapshot{
+		families: map[string]*dto.MetricFamily{
+			"job_trigger_latency_seconds": {
+				Name: proto.String("job_trigger_latency_seconds"),
+				Type: dto.MetricType_HISTOGRAM.Enum(),
+				Metric: []*dto.Metric{
+					{
+						Label: []*dto.LabelPair{
+							{Name: proto.String("namespace"), Value: proto.String("prod")},
+						},
+						Histogram: &dto.Histogram{
+							SampleCount: proto.Uint64(100),
+							Bucket: []*dto.Bucket{
+								{
+									UpperBound:      proto.Float64(0.1),
+									CumulativeCount: proto.Uint64(50),
+									Exemplar: &dto.Exemplar{
+										Value: proto.Float64(0.09),
+										Label: []*dto.LabelPair{
+											{Name: proto.String("trace_id"), Value: proto.String("trace-1")},
+											{Name: proto.String("span_id"), Value: proto.String("span-1")},
+										},
+									},
+								},
+								{UpperBound: proto.Float64(0.5), CumulativeCount: proto.Uint64(90)},
+								{UpperBound: proto.Float64(math.Inf(1)), CumulativeCount: proto.Uint64(100)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestMetricsSnapshotQuantile(t *testing.T) {
+	t.Parallel()
+
+	snap := newTestHistogramSnapshot()
+
+	t.Run("interpolates within a bucket", func(t *testing.T) {
+		t.Parallel()
+
+		v, ok := snap.Quantile("job_trigger_latency_seconds", map[string]string{"namespace": "prod"}, 0.5)
+		require.True(t, ok)
+		assert.InDelta(t, 0.1, v, 1e-9)
+	})
+
+	t.Run("unknown metric", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := snap.Quantile("does_not_exist", nil, 0.5)
+		assert.False(t, ok)
+	})
+
+	t.Run("label mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := snap.Quantile("job_trigger_latency_seconds", map[string]string{"namespace": "staging"}, 0.5)
+		assert.False(t, ok)
+	})
+}
+
+func TestMetricsSnapshotExemplars(t *testing.T) {
+	t.Parallel()
+
+	snap := newTestHistogramSnapshot()
+
+	exemplars := snap.Exemplars("job_trigger_latency_seconds", map[string]string{"namespace": "prod"})
+	require.Len(t, exemplars, 1)
+	assert.Equal(t, "trace-1", exemplars[0].TraceID)
+	assert.Equal(t, "span-1", exemplars[0].SpanID)
+	assert.InDelta(t, 0.09, exemplars[0].Value, 1e-9)
+}
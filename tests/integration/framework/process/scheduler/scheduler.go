@@ -63,6 +63,8 @@ type Scheduler struct {
 	initialCluster  string
 	etcdClientPorts map[string]string
 	sentry          *sentry.Sentry
+
+	httpGatewayPort *int
 }
 
 func New(t *testing.T, fopts ...Option) *Scheduler {
@@ -93,6 +95,9 @@ func New(t *testing.T, fopts ...Option) *Scheduler {
 		fopt(&opts)
 	}
 
+	require.False(t, opts.etcdPeerTLS && opts.sentry == nil,
+		"WithEtcdPeerTLS requires a Sentry option to also be set")
+
 	var dataDir string
 	if opts.dataDir != nil {
 		dataDir = *opts.dataDir
@@ -114,6 +119,17 @@ func New(t *testing.T, fopts ...Option) *Scheduler {
 		"--listen-address=" + opts.listenAddress,
 	}
 
+	if opts.httpGatewayPort != nil {
+		maxMsgBytes := opts.httpGatewayMaxMsgBytes
+		if maxMsgBytes <= 0 {
+			maxMsgBytes = defaultGatewayMaxResponseBodyBytes
+		}
+		args = append(args,
+			"--http-gateway-port="+strconv.Itoa(*opts.httpGatewayPort),
+			"--gateway-max-response-body-bytes="+strconv.Itoa(maxMsgBytes),
+		)
+	}
+
 	if opts.sentry != nil {
 		taFile := filepath.Join(t.TempDir(), "ca.pem")
 		require.NoError(t, os.WriteFile(taFile, opts.sentry.CABundle().TrustAnchors, 0o600))
@@ -123,6 +139,13 @@ func New(t *testing.T, fopts ...Option) *Scheduler {
 			"--trust-anchors-file="+taFile,
 			"--trust-domain="+opts.sentry.TrustDomain(t),
 		)
+
+		if opts.etcdPeerTLS {
+			args = append(args,
+				"--etcd-client-tls-enabled=true",
+				"--etcd-peer-tls-enabled=true",
+			)
+		}
 	}
 
 	clientPorts := make(map[string]string)
@@ -152,6 +175,7 @@ func New(t *testing.T, fopts ...Option) *Scheduler {
 		dataDir:         dataDir,
 		sentry:          opts.sentry,
 		namespace:       opts.namespace,
+		httpGatewayPort: opts.httpGatewayPort,
 	}
 }
 
@@ -279,6 +303,128 @@ func (s *Scheduler) ClientMTLS(t *testing.T, ctx context.Context, appID string)
 	return schedulerv1pb.NewSchedulerClient(conn)
 }
 
+// WithID overrides the scheduler's `--id`, which also identifies it within
+// `--initial-cluster`.
+func WithID(id string) Option {
+	return func(o *options) {
+		o.id = id
+	}
+}
+
+// WithReplicaCount overrides the scheduler's `--replica-count`, the size of
+// the cluster it expects to form.
+func WithReplicaCount(replicaCount uint32) Option {
+	return func(o *options) {
+		o.replicaCount = replicaCount
+	}
+}
+
+// WithInitialCluster overrides the scheduler's `--initial-cluster`, the
+// comma-separated `id=peerURL` list describing every member of the etcd
+// raft group it should join.
+func WithInitialCluster(initialCluster string) Option {
+	return func(o *options) {
+		o.initialCluster = initialCluster
+	}
+}
+
+// WithEtcdClientPorts overrides the scheduler's `--etcd-client-ports`, the
+// comma-separated `id=port` list of etcd client ports for every member of
+// the cluster.
+func WithEtcdClientPorts(etcdClientPorts []string) Option {
+	return func(o *options) {
+		o.etcdClientPorts = etcdClientPorts
+	}
+}
+
+// WithEtcdPeerTLS requires a Sentry option to also be set. It configures the
+// scheduler's embedded etcd to require mTLS on both its peer and client
+// endpoints, rather than only on the scheduler's own gRPC API.
+func WithEtcdPeerTLS() Option {
+	return func(o *options) {
+		o.etcdPeerTLS = true
+	}
+}
+
+// ETCDClientMTLS returns an etcd client authenticated with a SPIFFE identity
+// obtained from Sentry, for use against a scheduler started with
+// WithEtcdPeerTLS. It mirrors ClientMTLS, but dials the embedded etcd
+// endpoint instead of the scheduler's gRPC API.
+func (s *Scheduler) ETCDClientMTLS(t *testing.T, ctx context.Context) *client.EtcdClient {
+	t.Helper()
+
+	require.NotNil(t, s.sentry)
+
+	sec, err := security.New(ctx, security.Options{
+		SentryAddress:           "localhost:" + strconv.Itoa(s.sentry.Port()),
+		ControlPlaneTrustDomain: s.sentry.TrustDomain(t),
+		ControlPlaneNamespace:   s.sentry.Namespace(),
+		TrustAnchorsFile:        ptr.Of(s.sentry.TrustAnchorsFile(t)),
+		AppID:                   "dapr-scheduler-etcd-client",
+		Mode:                    modes.StandaloneMode,
+		MTLSEnabled:             true,
+		Healthz:                 healthz.New(),
+	})
+	require.NoError(t, err)
+
+	errCh := make(chan error)
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		errCh <- sec.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errCh)
+	})
+
+	sech, err := sec.Handler(ctx)
+	require.NoError(t, err)
+
+	id, err := spiffeid.FromSegments(sech.ControlPlaneTrustDomain(), "ns", s.namespace, "dapr-scheduler")
+	require.NoError(t, err)
+
+	return client.Etcd(t, clientv3.Config{
+		Endpoints:   []string{"127.0.0.1:" + s.EtcdClientPort()},
+		DialTimeout: 40 * time.Second,
+		TLS:         sech.MTLSClientConfig(id),
+	})
+}
+
+// Jobs queries the scheduler's ListJobs RPC with the given filter
+// expression (see ParseFilter), transparently following the server's
+// pagination tokens to collect every matching job. The filter is parsed
+// locally first so that a malformed expression fails fast with a clear
+// error rather than as an opaque RPC failure. Jobs requires a scheduler
+// binary whose ListJobs handler evaluates the filter against its etcd job
+// index; against a binary without that RPC, the call fails as unimplemented.
+func (s *Scheduler) Jobs(t *testing.T, ctx context.Context, filter string) []*schedulerv1pb.Job {
+	t.Helper()
+
+	_, err := ParseFilter(filter)
+	require.NoError(t, err, "invalid filter expression %q", filter)
+
+	cli := s.Client(t, ctx)
+
+	var jobs []*schedulerv1pb.Job
+	var pageToken string
+	for {
+		resp, err := cli.ListJobs(ctx, &schedulerv1pb.ListJobsRequest{
+			Filter:    filter,
+			PageToken: pageToken,
+		})
+		require.NoError(t, err)
+
+		jobs = append(jobs, resp.GetJobs()...)
+
+		pageToken = resp.GetNextPageToken()
+		if pageToken == "" {
+			break
+		}
+	}
+
+	return jobs
+}
+
 func (s *Scheduler) ipPort(port int) string {
 	return "127.0.0.1:" + strconv.Itoa(port)
 }
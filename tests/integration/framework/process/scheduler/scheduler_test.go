@@ -0,0 +1,29 @@
+/*
+Copyright 2024 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithEtcdPeerTLSRequiresSentry(t *testing.T) {
+	t.Parallel()
+
+	ok := t.Run("fails fast without a Sentry option", func(t *testing.T) {
+		New(t, WithEtcdPeerTLS())
+	})
+	assert.False(t, ok, "New should fail when WithEtcdPeerTLS is set without a Sentry option")
+}